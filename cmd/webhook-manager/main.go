@@ -0,0 +1,143 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog/v2"
+
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	vcinformers "volcano.sh/apis/pkg/client/informers/externalversions"
+
+	"volcano.sh/volcano/pkg/webhooks/router"
+
+	// Registers the hypernode validating webhook with the router package.
+	_ "volcano.sh/volcano/pkg/webhooks/admission/hypernodes/validate"
+)
+
+var (
+	kubeconfig   = flag.String("kubeconfig", "", "Path to a kubeconfig; only required when running out-of-cluster.")
+	bindAddress  = flag.String("webhook-bind-address", ":8443", "Address the webhook HTTPS server listens on.")
+	certFile     = flag.String("tls-cert-file", "", "TLS certificate file.")
+	keyFile      = flag.String("tls-private-key-file", "", "TLS private key file.")
+	resyncPeriod = flag.Duration("informer-resync-period", 0, "Resync period for the shared informer factory; 0 disables periodic resync.")
+	regexMaxLen  = flag.Int("hypernode-regex-match-max-length", 0, "Maximum length accepted for a HyperNode RegexMatch pattern; 0 uses the validating webhook's own default.")
+)
+
+func main() {
+	flag.Parse()
+
+	restConfig, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
+	if err != nil {
+		klog.Fatalf("failed to build kubeconfig: %v", err)
+	}
+
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("failed to build kube client: %v", err)
+	}
+
+	vcClient, err := vcclientset.NewForConfig(restConfig)
+	if err != nil {
+		klog.Fatalf("failed to build volcano client: %v", err)
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	// Start the shared hypernode informer and wait for its cache to sync before
+	// serving any admission requests, so the validating webhook's lister-backed
+	// lookups never race an empty cache.
+	informerFactory := vcinformers.NewSharedInformerFactory(vcClient, *resyncPeriod)
+	hyperNodeInformer := informerFactory.Topology().V1alpha1().HyperNodes()
+	informerFactory.Start(stopCh)
+
+	klog.Info("waiting for hypernode informer cache to sync")
+	if !cache.WaitForCacheSync(stopCh, hyperNodeInformer.Informer().HasSynced) {
+		klog.Fatal("failed to sync hypernode informer cache")
+	}
+	klog.Info("hypernode informer cache synced")
+
+	router.ForeachAdmission(func(service *router.AdmissionService) {
+		service.Config.KubeClient = kubeClient
+		service.Config.VolcanoClient = vcClient
+		service.Config.HyperNodeLister = hyperNodeInformer.Lister()
+		service.Config.RegexMatchMaxLength = *regexMaxLen
+	})
+
+	mux := http.NewServeMux()
+	router.ForeachAdmission(func(service *router.AdmissionService) {
+		mux.HandleFunc(service.Path, admissionHandler(service.Func))
+	})
+
+	server := &http.Server{
+		Addr:        *bindAddress,
+		Handler:     mux,
+		ReadTimeout: 10 * time.Second,
+	}
+
+	klog.Infof("webhook manager listening on %s", *bindAddress)
+	if *certFile != "" && *keyFile != "" {
+		klog.Fatal(server.ListenAndServeTLS(*certFile, *keyFile))
+	} else {
+		klog.Fatal(server.ListenAndServe())
+	}
+}
+
+// admissionHandler adapts an AdmitFunc into an http.HandlerFunc that decodes an
+// AdmissionReview request and writes back the resulting admission response.
+func admissionHandler(admit router.AdmitFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		var review admissionv1.AdmissionReview
+		if err := json.Unmarshal(body, &review); err != nil {
+			http.Error(w, fmt.Sprintf("failed to decode AdmissionReview: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		review.Response = admit(review)
+		if review.Response != nil && review.Request != nil {
+			review.Response.UID = review.Request.UID
+		}
+
+		out, err := json.Marshal(review)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to encode AdmissionReview: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(out); err != nil {
+			klog.Errorf("failed to write admission response: %v", err)
+		}
+	}
+}
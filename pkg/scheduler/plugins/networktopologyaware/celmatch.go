@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyaware
+
+import (
+	"fmt"
+
+	"volcano.sh/volcano/pkg/util/hypernode"
+)
+
+// celMatchProgramCacheSize bounds the number of compiled CELMatch programs this
+// plugin keeps around across scheduling cycles, mirroring the validating webhook's
+// own cache so a cluster with many distinct CELMatch expressions can't grow either
+// cache without limit.
+const celMatchProgramCacheSize = 256
+
+// celMatchCache holds compiled CELMatch programs so a HyperNode's CELMatch
+// expression is only compiled once no matter how many scheduling cycles or candidate
+// nodes it is evaluated against. The validating webhook already rejects malformed or
+// non-bool CELMatch expressions at admission time, so a compile failure here means
+// the expression was admitted before this plugin's CELNode shape existed, or the two
+// have drifted apart - either way it is reported rather than silently treated as "no
+// match".
+var celMatchCache = hypernode.NewProgramCache(celMatchProgramCacheSize)
+
+// CELMatchNode is the subset of scheduler node state a HyperNode CELMatch selector
+// can reference, copied field-for-field into a hypernode.CELNode at evaluation time.
+type CELMatchNode struct {
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+	Taints      []string // "key=value:effect"
+}
+
+// MatchesCELSelector reports whether node satisfies a HyperNode member's CELMatch
+// selector, compiling expr against the same hypernode.CELNode environment the
+// validating webhook uses and caching the compiled program across calls.
+func MatchesCELSelector(expr string, node CELMatchNode) (bool, error) {
+	program, err := hypernode.CompileBoolExpression(celMatchCache, expr, "CELMatch expression")
+	if err != nil {
+		return false, fmt.Errorf("invalid celMatch selector %q: %v", expr, err)
+	}
+
+	out, _, err := program.Eval(map[string]interface{}{
+		hypernode.NodeVariable: hypernode.CELNode{
+			Name:        node.Name,
+			Labels:      node.Labels,
+			Annotations: node.Annotations,
+			Spec:        hypernode.CELNodeSpec{Taints: node.Taints},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate celMatch selector %q: %v", expr, err)
+	}
+
+	matched, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("celMatch selector %q did not evaluate to bool", expr)
+	}
+	return matched, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networktopologyaware
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatchesCELSelector(t *testing.T) {
+	node := CELMatchNode{
+		Name:   "node-1",
+		Labels: map[string]string{"gpu": "a100", "rack": "rack-7"},
+		Taints: []string{"dedicated=gpu:NoSchedule"},
+	}
+
+	testCases := []struct {
+		Name        string
+		Expression  string
+		ExpectMatch bool
+		ExpectErr   string
+	}{
+		{
+			Name:        "matching label expression",
+			Expression:  `node.labels["gpu"] == "a100"`,
+			ExpectMatch: true,
+		},
+		{
+			Name:        "non-matching label expression",
+			Expression:  `node.labels["gpu"] == "v100"`,
+			ExpectMatch: false,
+		},
+		{
+			Name:        "matching name expression",
+			Expression:  `node.name == "node-1"`,
+			ExpectMatch: true,
+		},
+		{
+			Name:        "taint membership expression",
+			Expression:  `"dedicated=gpu:NoSchedule" in node.spec.taints`,
+			ExpectMatch: true,
+		},
+		{
+			Name:       "invalid expression is reported rather than treated as no match",
+			Expression: `node.bogus == "x"`,
+			ExpectErr:  "invalid celMatch selector",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			matched, err := MatchesCELSelector(testCase.Expression, node)
+			if testCase.ExpectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), testCase.ExpectErr) {
+					t.Fatalf("MatchesCELSelector: got err %v, want error containing %q", err, testCase.ExpectErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("MatchesCELSelector failed: %v", err)
+			}
+			if matched != testCase.ExpectMatch {
+				t.Errorf("MatchesCELSelector: got %v, want %v", matched, testCase.ExpectMatch)
+			}
+		})
+	}
+}
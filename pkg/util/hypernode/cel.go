@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hypernode holds the CEL environment and compiled-program cache shared by
+// every consumer of a HyperNode's CELMatch member selector: the validating webhook,
+// which compiles and rejects invalid expressions at admission time, and the
+// networktopologyaware scheduler plugin, which evaluates an already-admitted
+// expression against each candidate node. Keeping both in one place means the two
+// sides can never disagree about what a CELMatch expression is allowed to reference.
+package hypernode
+
+import (
+	"container/list"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/ext"
+)
+
+// NodeVariable is the single top-level variable a CELMatch expression is evaluated
+// against, e.g. `node.name == "node-1"` or `node.labels["gpu"] == "a100"`.
+const NodeVariable = "node"
+
+// CELNode is the node.* value a CELMatch expression sees.
+type CELNode struct {
+	Name        string            `cel:"name"`
+	Labels      map[string]string `cel:"labels"`
+	Annotations map[string]string `cel:"annotations"`
+	Spec        CELNodeSpec       `cel:"spec"`
+}
+
+// CELNodeSpec is CELNode's nested node.spec.* value.
+type CELNodeSpec struct {
+	Taints []string `cel:"taints"` // "key=value:effect"
+}
+
+// BuildCELEnv constructs the CEL environment CELMatch expressions are compiled and
+// evaluated in.
+//
+// node is exposed via ext.NativeTypes, which derives node's CEL field set from
+// CELNode's Go struct fields through reflection (named by their `cel` struct tags),
+// rather than as a dynamically typed
+// map: a map(string, dyn) lets `node.bogus` compile without complaint no matter how
+// wrong the expression is, since dot-selection on a dyn value is always legal, and
+// that failure would only surface (if at all) at Eval() time against a real node.
+// ext.NativeTypes gives node.name/node.labels/node.annotations/node.spec.taints real
+// compile-time field checking without needing a full proto-backed type.
+func BuildCELEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		ext.NativeTypes(reflect.TypeOf(CELNode{}), ext.ParseStructTags(true)),
+		cel.Variable(NodeVariable, cel.ObjectType("hypernode.CELNode")),
+	)
+}
+
+// ProgramCache is a bounded, thread-safe LRU of compiled CEL programs keyed by
+// expression text. Callers compile the same small set of distinct CELMatch
+// expressions repeatedly - once per admission, once per scheduling cycle per
+// candidate node - so caching the compiled cel.Program avoids paying to recompile
+// unchanged expressions.
+type ProgramCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[string]*list.Element
+}
+
+type programCacheEntry struct {
+	expr    string
+	program cel.Program
+}
+
+// NewProgramCache returns a ProgramCache holding at most capacity compiled programs.
+func NewProgramCache(capacity int) *ProgramCache {
+	return &ProgramCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached program for expr, if any.
+func (c *ProgramCache) Get(expr string) (cel.Program, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[expr]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*programCacheEntry).program, true
+}
+
+// Add caches program under expr, evicting the least recently used entry if the cache
+// is at capacity.
+func (c *ProgramCache) Add(expr string, program cel.Program) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[expr]; ok {
+		c.order.MoveToFront(elem)
+		elem.Value.(*programCacheEntry).program = program
+		return
+	}
+
+	elem := c.order.PushFront(&programCacheEntry{expr: expr, program: program})
+	c.entries[expr] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*programCacheEntry).expr)
+		}
+	}
+}
+
+// CompileBoolExpression compiles expr in the shared hypernode CEL environment,
+// rejecting it unless it type-checks to bool, and caches the compiled program in
+// cache so repeated compilations of the same expression are free. label identifies
+// the kind of expression being compiled (e.g. "CELMatch expression") in the
+// compile-error and non-bool-output error messages.
+func CompileBoolExpression(cache *ProgramCache, expr, label string) (cel.Program, error) {
+	if program, ok := cache.Get(expr); ok {
+		return program, nil
+	}
+
+	env, err := BuildCELEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL environment: %v", err)
+	}
+
+	ast, issues := env.Compile(expr)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid %s: %v", label, issues.Err())
+	}
+
+	if !ast.OutputType().IsExactType(types.BoolType) {
+		return nil, fmt.Errorf("%s must evaluate to bool, got %s", label, ast.OutputType())
+	}
+
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build CEL program: %v", err)
+	}
+
+	cache.Add(expr, program)
+	return program, nil
+}
@@ -21,8 +21,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/prometheus/client_golang/prometheus"
 	admissionv1 "k8s.io/api/admission/v1"
 	whv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 
@@ -36,8 +38,38 @@ const (
 	HyperNodeLabel = "volcano.sh/hypernodes"
 )
 
+// config.HyperNodeLister is populated by cmd/webhook-manager's main, which starts the
+// shared hypernode informer and waits for its cache to sync before handing the lister
+// off to every registered AdmissionService. Until that informer's cache has synced (or
+// when running this package outside that bootstrap without setting it; the tests here
+// seed their own lister), HyperNodeLister is nil and getHyperNode below always falls
+// through to a live apiserver Get.
 var config = &router.AdmissionServiceConfig{}
 
+var (
+	hyperNodeCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "volcano_hypernode_webhook_cache_hits_total",
+		Help: "Number of hypernode lookups served from the informer cache during admission.",
+	})
+	hyperNodeCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "volcano_hypernode_webhook_cache_misses_total",
+		Help: "Number of hypernode lookups that fell back to a live apiserver Get because the informer cache did not have the entry yet.",
+	})
+)
+
+func init() {
+	// Register with prometheus.Register rather than MustRegister: this package may be
+	// loaded alongside other webhooks that already expose collectors under the same
+	// name (e.g. in tests that import the package multiple times), and a duplicate
+	// registration should degrade to a log line, not a panic that takes down the
+	// webhook-manager process.
+	for _, collector := range []prometheus.Collector{hyperNodeCacheHits, hyperNodeCacheMisses} {
+		if err := prometheus.Register(collector); err != nil {
+			klog.Warningf("failed to register hypernode webhook cache metric: %v", err)
+		}
+	}
+}
+
 var service = &router.AdmissionService{
 	Path: "/hypernodes/validate",
 	Func: AdmitHyperNode,
@@ -98,6 +130,26 @@ func AdmitHyperNode(ar admissionv1.AdmissionReview) *admissionv1.AdmissionRespon
 	}
 }
 
+// getHyperNode resolves a hypernode by name, preferring the shared informer cache
+// (config.HyperNodeLister, populated by the webhook bootstrap) and falling back to a
+// live apiserver Get when the cache reports NotFound, which happens when a sibling
+// hypernode was just created and has not been observed by the informer yet.
+func getHyperNode(name string) (*hypernodev1alpha1.HyperNode, error) {
+	if config.HyperNodeLister != nil {
+		hypernode, err := config.HyperNodeLister.Get(name)
+		if err == nil {
+			hyperNodeCacheHits.Inc()
+			return hypernode, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+	}
+
+	hyperNodeCacheMisses.Inc()
+	return config.VolcanoClient.TopologyV1alpha1().HyperNodes().Get(context.TODO(), name, metav1.GetOptions{})
+}
+
 // validateHyperNodeCreate is to validate hypernode create
 func validateHyperNodeCreate(hypernode *hypernodev1alpha1.HyperNode) error {
 	if err := validateHyperNodeMembers(hypernode); err != nil {
@@ -112,13 +164,13 @@ func validateHyperNodeCreate(hypernode *hypernodev1alpha1.HyperNode) error {
 				return fmt.Errorf("the label %s must be like `hypernode-0,hypernode-1,...,hypernode-n`", HyperNodeLabel)
 			}
 
-			if _, err := config.VolcanoClient.TopologyV1alpha1().HyperNodes().Get(context.TODO(), hypernodeName, metav1.GetOptions{}); err != nil {
+			if _, err := getHyperNode(hypernodeName); err != nil {
 				return fmt.Errorf("failed to get hypernode %s: %v", hypernodeName, err)
 			}
 		}
 	}
 
-	return nil
+	return validateHyperNodeTopology(hypernode)
 }
 
 // validateHyperNodeUpdate is to validate hypernode update
@@ -140,7 +192,7 @@ func validateHyperNodeUpdate(oldHyperNode, hypernode *hypernodev1alpha1.HyperNod
 
 	// set hypernode list to empty is ok
 	if len(newHyperNodeList) == 0 {
-		return nil
+		return validateHyperNodeTopology(hypernode)
 	}
 
 	// change hypernode list length is not allowed
@@ -155,7 +207,7 @@ func validateHyperNodeUpdate(oldHyperNode, hypernode *hypernodev1alpha1.HyperNod
 				return fmt.Errorf("the label %s must be like `hypernode-0,hypernode-1,...,hypernode-n`", HyperNodeLabel)
 			}
 
-			hypernode, err := config.VolcanoClient.TopologyV1alpha1().HyperNodes().Get(context.TODO(), newHyperNodeList[i], metav1.GetOptions{})
+			hypernode, err := getHyperNode(newHyperNodeList[i])
 			if err != nil {
 				return fmt.Errorf("failed to get hypernode %s: %v", newHyperNodeList[i], err)
 			}
@@ -166,7 +218,7 @@ func validateHyperNodeUpdate(oldHyperNode, hypernode *hypernodev1alpha1.HyperNod
 		}
 	}
 
-	return nil
+	return validateHyperNodeTopology(hypernode)
 }
 
 // validateHyperNodeMembers is to validate hypernode members
@@ -180,8 +232,30 @@ func validateHyperNodeMembers(hypernode *hypernodev1alpha1.HyperNode) error {
 			continue
 		}
 
-		if member.Selector.ExactMatch != nil && member.Selector.RegexMatch != nil {
-			return fmt.Errorf("exactMatch and regexMatch cannot be specified together")
+		selectorsSet := 0
+		if member.Selector.ExactMatch != nil {
+			selectorsSet++
+		}
+		if member.Selector.RegexMatch != nil {
+			selectorsSet++
+		}
+		if member.Selector.CELMatch != nil {
+			selectorsSet++
+		}
+		if selectorsSet > 1 {
+			return fmt.Errorf("exactMatch, regexMatch and celMatch are mutually exclusive")
+		}
+
+		if member.Selector.CELMatch != nil {
+			if _, err := compileCELMatch(member.Selector.CELMatch.Expression); err != nil {
+				return fmt.Errorf("invalid celMatch selector: %v", err)
+			}
+		}
+
+		if member.Selector.RegexMatch != nil {
+			if err := validateRegexMatchPattern(member.Selector.RegexMatch.Pattern); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
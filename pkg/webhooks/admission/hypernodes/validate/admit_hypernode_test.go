@@ -20,11 +20,33 @@ import (
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
 
 	hypernodev1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
 	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+	hypernodelisters "volcano.sh/apis/pkg/client/listers/topology/v1alpha1"
 )
 
+// newHyperNodeLister builds a lister backed by an informer indexer seeded with the
+// given hypernodes, standing in for the shared informer the webhook bootstrap starts.
+func newHyperNodeLister(t *testing.T, hypernodes ...*hypernodev1alpha1.HyperNode) hypernodelisters.HyperNodeLister {
+	t.Helper()
+
+	objs := make([]interface{}, 0, len(hypernodes))
+	for _, hypernode := range hypernodes {
+		objs = append(objs, hypernode)
+	}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("failed to seed hypernode informer cache: %v", err)
+		}
+	}
+
+	return hypernodelisters.NewHyperNodeLister(indexer)
+}
+
 func TestValidateHyperNodeMembers(t *testing.T) {
 	testCases := []struct {
 		Name      string
@@ -79,7 +101,7 @@ func TestValidateHyperNodeMembers(t *testing.T) {
 					},
 				},
 			},
-			ExpectErr: "exactMatch and regexMatch cannot be specified together",
+			ExpectErr: "exactMatch, regexMatch and celMatch are mutually exclusive",
 		},
 	}
 
@@ -137,37 +159,35 @@ func TestValidateCreatedHyperNodeLabels(t *testing.T) {
 	}
 
 	// create hyper node for test
-	hypernodeList := &hypernodev1alpha1.HyperNodeList{
-		Items: []hypernodev1alpha1.HyperNode{
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "hypernode-0",
-				},
-				Spec: hypernodev1alpha1.HyperNodeSpec{
-					Tier: "1",
-				},
-			},
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "hypernode-1",
-				},
-				Spec: hypernodev1alpha1.HyperNodeSpec{
-					Tier: "2",
-				},
-			},
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "hypernode-2",
-				},
-				Spec: hypernodev1alpha1.HyperNodeSpec{
-					Tier: "3",
-				},
-			},
+	hypernode0 := &hypernodev1alpha1.HyperNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hypernode-0",
+		},
+		Spec: hypernodev1alpha1.HyperNodeSpec{
+			Tier: "1",
+		},
+	}
+	hypernode1 := &hypernodev1alpha1.HyperNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hypernode-1",
+		},
+		Spec: hypernodev1alpha1.HyperNodeSpec{
+			Tier: "2",
+		},
+	}
+	hypernode2 := &hypernodev1alpha1.HyperNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "hypernode-2",
+		},
+		Spec: hypernodev1alpha1.HyperNodeSpec{
+			Tier: "3",
 		},
 	}
 
-	client := fakeclient.NewSimpleClientset(hypernodeList)
-	config.VolcanoClient = client
+	// the lister is seeded with hypernode-0 and hypernode-1 only; the hypernode-3 case
+	// misses the lister and exercises the live-Get fallback against the fake client below.
+	config.HyperNodeLister = newHyperNodeLister(t, hypernode0, hypernode1)
+	config.VolcanoClient = fakeclient.NewSimpleClientset(hypernode0, hypernode1, hypernode2)
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
@@ -299,8 +319,10 @@ func TestValidateUpdateHyperNodeLabels(t *testing.T) {
 		},
 	}
 
-	client := fakeclient.NewSimpleClientset(oldHyperNode, hyperNode1, hyperNodeNew, hyperNode3)
-	config.VolcanoClient = client
+	// the lister omits hyperNode3, so the "change hypernode" case touching hypernode-3
+	// must exercise the live-Get fallback against the fake client below.
+	config.HyperNodeLister = newHyperNodeLister(t, oldHyperNode, hyperNode1, hyperNodeNew)
+	config.VolcanoClient = fakeclient.NewSimpleClientset(oldHyperNode, hyperNode1, hyperNodeNew, hyperNode3)
 
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(t *testing.T) {
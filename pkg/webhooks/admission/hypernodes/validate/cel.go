@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"github.com/google/cel-go/cel"
+
+	"volcano.sh/volcano/pkg/util/hypernode"
+)
+
+// celMemberSelectorCacheSize bounds the number of compiled CELMatch programs kept
+// around, so a cluster with many distinct expressions can't grow this without limit.
+const celMemberSelectorCacheSize = 256
+
+var celCache = hypernode.NewProgramCache(celMemberSelectorCacheSize)
+
+// compileCELMatch compiles a CELMatch expression against the hypernode.CELNode
+// environment shared with the networktopologyaware scheduler plugin, rejecting it
+// unless it type-checks to bool, and caches the compiled program so subsequent
+// admissions of the same expression skip recompilation.
+func compileCELMatch(expr string) (cel.Program, error) {
+	return hypernode.CompileBoolExpression(celCache, expr, "CELMatch expression")
+}
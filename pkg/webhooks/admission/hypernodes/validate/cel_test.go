@@ -0,0 +1,177 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	hypernodev1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+)
+
+func TestValidateHyperNodeMembersCELMatch(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		HyperNode *hypernodev1alpha1.HyperNode
+		ExpectErr string
+	}{
+		{
+			Name: "valid celMatch expression",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `node.labels["gpu"] == "a100" && node.labels["rack"] == "rack-7"`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "",
+		},
+		{
+			Name: "celMatch with parse error",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `node.labels["gpu"] ==`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "invalid celMatch selector",
+		},
+		{
+			Name: "celMatch with non-bool output type",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `node.name`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "CELMatch expression must evaluate to bool",
+		},
+		{
+			// Regression test: node is exposed via ext.NativeTypes rather than a
+			// dynamically typed map, so referencing a field CELNode does not declare
+			// is a genuine compile-time failure instead of silently compiling.
+			Name: "celMatch referencing an undeclared node field is rejected",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `node.bogus == "x"`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "invalid celMatch selector",
+		},
+		{
+			Name: "celMatch referencing an undeclared variable is rejected",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `nonsense == "x"`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "invalid celMatch selector",
+		},
+		{
+			Name: "celMatch combined with exactMatch is rejected",
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Members: []hypernodev1alpha1.MemberSpec{
+						{
+							Selector: hypernodev1alpha1.MemberSelector{
+								ExactMatch: &hypernodev1alpha1.ExactMatch{Name: "node-1"},
+								CELMatch: &hypernodev1alpha1.CELMatch{
+									Expression: `node.name == "node-1"`,
+								},
+							},
+						},
+					},
+				},
+			},
+			ExpectErr: "exactMatch, regexMatch and celMatch are mutually exclusive",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := validateHyperNodeMembers(testCase.HyperNode)
+			if testCase.ExpectErr == "" {
+				if err != nil {
+					t.Errorf("validateHyperNodeMembers failed: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectErr) {
+				t.Errorf("validateHyperNodeMembers: got %v, want error containing %q", err, testCase.ExpectErr)
+			}
+		})
+	}
+}
+
+func TestCompileCELMatchCachesProgram(t *testing.T) {
+	expr := `node.labels["zone"] == "us-east-1"`
+
+	first, err := compileCELMatch(expr)
+	if err != nil {
+		t.Fatalf("compileCELMatch failed: %v", err)
+	}
+
+	second, err := compileCELMatch(expr)
+	if err != nil {
+		t.Fatalf("compileCELMatch failed: %v", err)
+	}
+
+	if _, ok := celCache.Get(expr); !ok {
+		t.Fatalf("expected compiled program to be cached")
+	}
+
+	if first == nil || second == nil {
+		t.Fatalf("expected non-nil compiled programs")
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// defaultRegexMatchMaxLength bounds RegexMatch.Pattern length when
+// config.RegexMatchMaxLength is unset, keeping pathological patterns out even when
+// the admission service config hasn't been tuned for the cluster.
+const defaultRegexMatchMaxLength = 256
+
+// nestedQuantifierPattern flags the classic catastrophic-backtracking shapes, a
+// quantified group immediately wrapped in another quantifier, e.g. "(.+)+" or "(.*)*".
+var nestedQuantifierPattern = regexp.MustCompile(`\([^()]*[+*][^()]*\)[+*]`)
+
+// validateRegexMatchPattern compiles pattern and rejects it on syntax error or on
+// obvious ReDoS risk: patterns longer than the configured threshold, or patterns
+// containing a nested quantifier.
+func validateRegexMatchPattern(pattern string) error {
+	maxLen := config.RegexMatchMaxLength
+	if maxLen <= 0 {
+		maxLen = defaultRegexMatchMaxLength
+	}
+
+	if len(pattern) > maxLen {
+		return fmt.Errorf("regexMatch pattern length %d exceeds the maximum of %d", len(pattern), maxLen)
+	}
+
+	if nestedQuantifierPattern.MatchString(pattern) {
+		return fmt.Errorf("regexMatch pattern %q contains a nested quantifier which risks catastrophic backtracking", pattern)
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid regexMatch pattern %q: %v", pattern, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	hypernodev1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+)
+
+func TestValidateHyperNodeMembersRegexMatch(t *testing.T) {
+	regexMatchHyperNode := func(pattern string) *hypernodev1alpha1.HyperNode {
+		return &hypernodev1alpha1.HyperNode{
+			Spec: hypernodev1alpha1.HyperNodeSpec{
+				Members: []hypernodev1alpha1.MemberSpec{
+					{
+						Selector: hypernodev1alpha1.MemberSelector{
+							RegexMatch: &hypernodev1alpha1.RegexMatch{Pattern: pattern},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name      string
+		HyperNode *hypernodev1alpha1.HyperNode
+		ExpectErr string
+	}{
+		{
+			Name:      "happy path pattern",
+			HyperNode: regexMatchHyperNode("^node-[0-9]+$"),
+			ExpectErr: "",
+		},
+		{
+			Name:      "malformed pattern",
+			HyperNode: regexMatchHyperNode("node-[0-9"),
+			ExpectErr: "invalid regexMatch pattern",
+		},
+		{
+			Name:      "nested quantifier dot-plus",
+			HyperNode: regexMatchHyperNode("(.+)+"),
+			ExpectErr: "nested quantifier",
+		},
+		{
+			Name:      "nested quantifier dot-star",
+			HyperNode: regexMatchHyperNode("(.*)*"),
+			ExpectErr: "nested quantifier",
+		},
+		{
+			Name:      "pattern exceeds maximum length",
+			HyperNode: regexMatchHyperNode(strings.Repeat("a", defaultRegexMatchMaxLength+1)),
+			ExpectErr: "exceeds the maximum",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := validateHyperNodeMembers(testCase.HyperNode)
+			if testCase.ExpectErr == "" {
+				if err != nil {
+					t.Errorf("validateHyperNodeMembers failed: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), testCase.ExpectErr) {
+				t.Errorf("validateHyperNodeMembers: got %v, want error containing %q", err, testCase.ExpectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	hypernodev1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+)
+
+// dfsColor marks DFS visitation state while walking the hypernode graph for cycles.
+type dfsColor int
+
+const (
+	white dfsColor = iota // not yet visited
+	gray                  // on the current DFS stack
+	black                 // fully explored
+)
+
+// listAllHyperNodes returns every hypernode known to the cluster, preferring the
+// shared informer lister and falling back to a live List when it is not populated.
+func listAllHyperNodes() ([]*hypernodev1alpha1.HyperNode, error) {
+	if config.HyperNodeLister != nil {
+		return config.HyperNodeLister.List(labels.Everything())
+	}
+
+	list, err := config.VolcanoClient.TopologyV1alpha1().HyperNodes().List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	hypernodes := make([]*hypernodev1alpha1.HyperNode, 0, len(list.Items))
+	for i := range list.Items {
+		hypernodes = append(hypernodes, &list.Items[i])
+	}
+	return hypernodes, nil
+}
+
+// validateHyperNodeTopology reconstructs the parent-child graph implied by the
+// HyperNodeLabel across the cluster (substituting the proposed state of hypernode
+// for whatever is currently stored under its name) and rejects admission if the
+// resulting DAG is not well-formed: no cycles reachable from the admitted hypernode,
+// strict tier monotonicity between a tier>1 HyperNode and the children it lists and
+// exactly one ancestor per referenced HyperNode (both scoped to the connected component
+// touched by this admission), and at most one tier-1 owner per leaf node (checked
+// cluster-wide: two tier-1 HyperNodes claiming the same node never share a
+// HyperNodeLabel edge, so component-scoping that check would hide the exact
+// collision this is meant to catch).
+func validateHyperNodeTopology(hypernode *hypernodev1alpha1.HyperNode) error {
+	all, err := listAllHyperNodes()
+	if err != nil {
+		return fmt.Errorf("failed to list hypernodes for topology validation: %v", err)
+	}
+
+	byName := make(map[string]*hypernodev1alpha1.HyperNode, len(all))
+	for _, hn := range all {
+		byName[hn.Name] = hn
+	}
+	// The proposed object always wins over whatever is currently stored, since that
+	// is the state the graph would have if this admission is allowed.
+	byName[hypernode.Name] = hypernode
+
+	children := make(map[string][]string, len(byName))
+	for name, hn := range byName {
+		children[name] = hyperNodeChildren(hn)
+	}
+
+	if cycle, ok := findCycle(hypernode.Name, children); ok {
+		return fmt.Errorf("cycle: %s", strings.Join(cycle, " -> "))
+	}
+
+	if err := checkTierMonotonicity(hypernode.Name, children, byName); err != nil {
+		return err
+	}
+
+	// Scope the ancestor check to the connected component reachable from the admitted
+	// hypernode: materializing the whole cluster graph on every admission would let an
+	// unrelated multi-ancestor pair elsewhere in the cluster block admissions that
+	// don't touch it, and ancestry is only ever in dispute between HyperNodes that
+	// share a HyperNodeLabel edge.
+	component := connectedComponent(hypernode.Name, children)
+
+	componentNames := make([]string, 0, len(component))
+	componentChildren := make(map[string][]string, len(component))
+	for name := range component {
+		componentNames = append(componentNames, name)
+		componentChildren[name] = children[name]
+	}
+	sort.Strings(componentNames)
+
+	for _, name := range componentNames {
+		if err := checkTierMonotonicity(name, children, byName); err != nil {
+			return err
+		}
+	}
+
+	if err := checkSingleAncestor(componentChildren); err != nil {
+		return err
+	}
+
+	// Leaf ownership is checked against every tier-1 HyperNode in the cluster, not just
+	// the connected component: two tier-1 HyperNodes that both claim the same node via
+	// ExactMatch have no HyperNodeLabel edge between them (tier-1 is the floor of the
+	// hierarchy), so they are never in the same component, and component-scoping this
+	// check would let that exact collision through.
+	if err := checkLeafOwnership(byName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// connectedComponent returns the set of hypernode names reachable from start by
+// following HyperNodeLabel edges in either direction (parent->child or child->parent).
+func connectedComponent(start string, children map[string][]string) map[string]bool {
+	parents := make(map[string][]string, len(children))
+	for parent, kids := range children {
+		for _, child := range kids {
+			parents[child] = append(parents[child], parent)
+		}
+	}
+
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		neighbors := make([]string, 0, len(children[name])+len(parents[name]))
+		neighbors = append(neighbors, children[name]...)
+		neighbors = append(neighbors, parents[name]...)
+
+		for _, neighbor := range neighbors {
+			if !visited[neighbor] {
+				visited[neighbor] = true
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+
+	return visited
+}
+
+// hyperNodeChildren returns the child hypernode names a HyperNode lists via
+// HyperNodeLabel, excluding the hypernode's own name: the baseline hypernode-0 fixture
+// lists itself alongside its siblings, and that self-reference is not a cycle.
+func hyperNodeChildren(hypernode *hypernodev1alpha1.HyperNode) []string {
+	if hypernode.Labels == nil || hypernode.Labels[HyperNodeLabel] == "" {
+		return nil
+	}
+
+	names := strings.Split(hypernode.Labels[HyperNodeLabel], ",")
+	children := make([]string, 0, len(names))
+	for _, name := range names {
+		if name == hypernode.Name {
+			continue
+		}
+		children = append(children, name)
+	}
+	return children
+}
+
+// findCycle runs a DFS with white/gray/black colouring starting from start and
+// reports the first back-edge found as a human-readable path, e.g.
+// "hypernode-a -> hypernode-b -> hypernode-a".
+func findCycle(start string, children map[string][]string) ([]string, bool) {
+	colors := make(map[string]dfsColor, len(children))
+	var path []string
+
+	var visit func(name string) ([]string, bool)
+	visit = func(name string) ([]string, bool) {
+		colors[name] = gray
+		path = append(path, name)
+
+		for _, child := range children[name] {
+			switch colors[child] {
+			case gray:
+				return append(append([]string{}, path...), child), true
+			case black:
+				continue
+			default:
+				if cycle, ok := visit(child); ok {
+					return cycle, true
+				}
+			}
+		}
+
+		path = path[:len(path)-1]
+		colors[name] = black
+		return nil, false
+	}
+
+	return visit(start)
+}
+
+// checkTierMonotonicity verifies that a HyperNode of tier T > 1 only lists children
+// whose spec.tier is numerically less than T.
+//
+// Tier-1 HyperNodes are exempt: per validateHyperNodeUpdate, the HyperNodeLabel on a
+// tier-1 HyperNode enumerates its tier-1 siblings (and conventionally includes its own
+// name, filtered out by hyperNodeChildren above), not a lower tier it groups - tier 1
+// is the floor of the hierarchy, so there is nothing strictly below it to enforce.
+func checkTierMonotonicity(name string, children map[string][]string, byName map[string]*hypernodev1alpha1.HyperNode) error {
+	parent, ok := byName[name]
+	if !ok {
+		return nil
+	}
+
+	parentTier, err := strconv.Atoi(parent.Spec.Tier)
+	if err != nil {
+		return nil // tier is validated elsewhere; nothing to compare against.
+	}
+
+	if parentTier <= 1 {
+		return nil
+	}
+
+	for _, childName := range children[name] {
+		child, ok := byName[childName]
+		if !ok {
+			continue // missing children are reported by validateHyperNodeCreate/Update.
+		}
+
+		childTier, err := strconv.Atoi(child.Spec.Tier)
+		if err != nil {
+			continue
+		}
+
+		if childTier >= parentTier {
+			return fmt.Errorf("hypernode %s (tier %d) cannot list %s (tier %d) as a child: child tier must be strictly lower", name, parentTier, childName, childTier)
+		}
+	}
+
+	return nil
+}
+
+// checkSingleAncestor verifies that every HyperNode referenced by a parent's label
+// has that parent as its only ancestor.
+func checkSingleAncestor(children map[string][]string) error {
+	ancestor := make(map[string]string, len(children))
+
+	parents := make([]string, 0, len(children))
+	for parent := range children {
+		parents = append(parents, parent)
+	}
+	sort.Strings(parents)
+
+	for _, parent := range parents {
+		for _, child := range children[parent] {
+			if existing, ok := ancestor[child]; ok && existing != parent {
+				return fmt.Errorf("hypernode %s cannot have both %s and %s as ancestors", child, existing, parent)
+			}
+			ancestor[child] = parent
+		}
+	}
+
+	return nil
+}
+
+// checkLeafOwnership verifies each leaf node named by ExactMatch belongs to at most
+// one tier-1 HyperNode within the given set of HyperNodes, which callers must pass as
+// every HyperNode in the cluster - a collision between two tier-1 HyperNodes is never
+// confined to a connected component, since tier-1 HyperNodes that both claim the same
+// node have no HyperNodeLabel edge between them. RegexMatch/CELMatch selectors are
+// deliberately not expanded here: doing so needs the live cluster node list to know
+// which names a pattern matches, which the admission webhook does not have, so
+// collisions hidden behind those selector kinds are an accepted limitation rather than
+// an oversight.
+func checkLeafOwnership(byName map[string]*hypernodev1alpha1.HyperNode) error {
+	owner := make(map[string]string)
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		hn := byName[name]
+		if hn.Spec.Tier != "1" {
+			continue
+		}
+
+		for _, member := range hn.Spec.Members {
+			if member.Selector.ExactMatch == nil {
+				continue
+			}
+
+			nodeName := member.Selector.ExactMatch.Name
+			if existing, ok := owner[nodeName]; ok && existing != name {
+				return fmt.Errorf("node %s belongs to more than one tier-1 hypernode: %s and %s", nodeName, existing, name)
+			}
+			owner[nodeName] = name
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hypernodev1alpha1 "volcano.sh/apis/pkg/apis/topology/v1alpha1"
+	fakeclient "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+)
+
+func tierHyperNode(name, tier, parentLabel string) *hypernodev1alpha1.HyperNode {
+	hn := &hypernodev1alpha1.HyperNode{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: hypernodev1alpha1.HyperNodeSpec{
+			Tier: tier,
+		},
+	}
+	if parentLabel != "" {
+		hn.Labels = map[string]string{HyperNodeLabel: parentLabel}
+	}
+	return hn
+}
+
+func TestValidateHyperNodeTopology(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Existing  []*hypernodev1alpha1.HyperNode
+		HyperNode *hypernodev1alpha1.HyperNode
+		ExpectErr string
+	}{
+		{
+			Name: "valid two tier topology",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-0", "1", ""),
+				tierHyperNode("hypernode-1", "1", ""),
+			},
+			HyperNode: tierHyperNode("hypernode-2", "2", "hypernode-0,hypernode-1"),
+			ExpectErr: "",
+		},
+		{
+			Name: "cycle between two hypernodes",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-a", "1", "hypernode-b"),
+			},
+			HyperNode: tierHyperNode("hypernode-b", "2", "hypernode-a"),
+			ExpectErr: "cycle: hypernode-b -> hypernode-a -> hypernode-b",
+		},
+		{
+			Name: "tier monotonicity violation",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-0", "3", ""),
+			},
+			HyperNode: tierHyperNode("hypernode-2", "2", "hypernode-0"),
+			ExpectErr: "hypernode hypernode-2 (tier 2) cannot list hypernode-0 (tier 3) as a child: child tier must be strictly lower",
+		},
+		{
+			Name: "child with two ancestors",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-0", "1", ""),
+				tierHyperNode("hypernode-parent-a", "2", "hypernode-0"),
+			},
+			HyperNode: tierHyperNode("hypernode-parent-b", "2", "hypernode-0"),
+			ExpectErr: "hypernode hypernode-0 cannot have both hypernode-parent-a and hypernode-parent-b as ancestors",
+		},
+		{
+			// Regression test: baseline fixtures (admit_hypernode_test.go) model a
+			// tier-1 HyperNode whose label lists itself plus a higher-tier sibling,
+			// e.g. hypernode-0 (tier 1) labelled "hypernode-0,hypernode-1" where
+			// hypernode-1 is tier 2. That must keep admitting cleanly: tier-1 is the
+			// floor of the hierarchy and is exempt from the "child tier < parent tier"
+			// rule, and the self-reference must not be reported as a cycle.
+			Name: "tier-1 hypernode listing itself and a higher-tier sibling is allowed",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-1", "2", ""),
+			},
+			HyperNode: tierHyperNode("hypernode-0", "1", "hypernode-0,hypernode-1"),
+			ExpectErr: "",
+		},
+		{
+			Name:      "hypernode label that only references itself is allowed",
+			HyperNode: tierHyperNode("hypernode-5", "1", "hypernode-5"),
+			ExpectErr: "",
+		},
+		{
+			// Regression test: checkSingleAncestor may be scoped to the connected
+			// component touched by this admission, so an unrelated multi-ancestor
+			// pair elsewhere must not block admission of a disconnected hypernode.
+			Name: "unrelated multi-ancestor pair elsewhere does not block this admission",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				tierHyperNode("hypernode-0", "1", ""),
+				tierHyperNode("hypernode-parent-a", "2", "hypernode-0"),
+				tierHyperNode("hypernode-parent-b", "2", "hypernode-0"),
+			},
+			HyperNode: tierHyperNode("hypernode-unrelated", "1", ""),
+			ExpectErr: "",
+		},
+		{
+			// Regression test: leaf ownership must be checked cluster-wide, not just
+			// within the connected component - hypernode-dup-a and hypernode-dup-b
+			// share no HyperNodeLabel edge, so this collision would be invisible to a
+			// component-scoped check.
+			Name: "duplicate leaf ownership is rejected even with no shared label edge",
+			Existing: []*hypernodev1alpha1.HyperNode{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "hypernode-dup-a"},
+					Spec: hypernodev1alpha1.HyperNodeSpec{
+						Tier: "1",
+						Members: []hypernodev1alpha1.MemberSpec{{
+							Selector: hypernodev1alpha1.MemberSelector{ExactMatch: &hypernodev1alpha1.ExactMatch{Name: "node-x"}},
+						}},
+					},
+				},
+			},
+			HyperNode: &hypernodev1alpha1.HyperNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "hypernode-dup-b"},
+				Spec: hypernodev1alpha1.HyperNodeSpec{
+					Tier: "1",
+					Members: []hypernodev1alpha1.MemberSpec{{
+						Selector: hypernodev1alpha1.MemberSelector{ExactMatch: &hypernodev1alpha1.ExactMatch{Name: "node-x"}},
+					}},
+				},
+			},
+			ExpectErr: "node node-x belongs to more than one tier-1 hypernode: hypernode-dup-a and hypernode-dup-b",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			config.HyperNodeLister = newHyperNodeLister(t, testCase.Existing...)
+			config.VolcanoClient = fakeclient.NewSimpleClientset()
+
+			err := validateHyperNodeTopology(testCase.HyperNode)
+			if testCase.ExpectErr == "" {
+				if err != nil {
+					t.Errorf("validateHyperNodeTopology failed: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.ExpectErr {
+				t.Errorf("validateHyperNodeTopology: got %v, want %q", err, testCase.ExpectErr)
+			}
+		})
+	}
+}
+
+func TestCheckLeafOwnership(t *testing.T) {
+	exactMatch := func(name string) hypernodev1alpha1.MemberSpec {
+		return hypernodev1alpha1.MemberSpec{
+			Selector: hypernodev1alpha1.MemberSelector{
+				ExactMatch: &hypernodev1alpha1.ExactMatch{Name: name},
+			},
+		}
+	}
+
+	testCases := []struct {
+		Name      string
+		ByName    map[string]*hypernodev1alpha1.HyperNode
+		ExpectErr string
+	}{
+		{
+			Name: "node owned by a single tier-1 hypernode",
+			ByName: map[string]*hypernodev1alpha1.HyperNode{
+				"hypernode-0": {
+					ObjectMeta: metav1.ObjectMeta{Name: "hypernode-0"},
+					Spec: hypernodev1alpha1.HyperNodeSpec{
+						Tier:    "1",
+						Members: []hypernodev1alpha1.MemberSpec{exactMatch("node-1")},
+					},
+				},
+			},
+			ExpectErr: "",
+		},
+		{
+			Name: "node claimed by two tier-1 hypernodes",
+			ByName: map[string]*hypernodev1alpha1.HyperNode{
+				"hypernode-0": {
+					ObjectMeta: metav1.ObjectMeta{Name: "hypernode-0"},
+					Spec: hypernodev1alpha1.HyperNodeSpec{
+						Tier:    "1",
+						Members: []hypernodev1alpha1.MemberSpec{exactMatch("node-1")},
+					},
+				},
+				"hypernode-1": {
+					ObjectMeta: metav1.ObjectMeta{Name: "hypernode-1"},
+					Spec: hypernodev1alpha1.HyperNodeSpec{
+						Tier:    "1",
+						Members: []hypernodev1alpha1.MemberSpec{exactMatch("node-1")},
+					},
+				},
+			},
+			ExpectErr: "node node-1 belongs to more than one tier-1 hypernode: hypernode-0 and hypernode-1",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			err := checkLeafOwnership(testCase.ByName)
+			if testCase.ExpectErr == "" {
+				if err != nil {
+					t.Errorf("checkLeafOwnership failed: %v", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.ExpectErr {
+				t.Errorf("checkLeafOwnership: got %v, want %q", err, testCase.ExpectErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	admissionv1 "k8s.io/api/admission/v1"
+	whv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/client-go/kubernetes"
+
+	vcclientset "volcano.sh/apis/pkg/client/clientset/versioned"
+	hypernodelisters "volcano.sh/apis/pkg/client/listers/topology/v1alpha1"
+)
+
+// AdmitFunc handles a single AdmissionReview and returns the resulting AdmissionResponse.
+type AdmitFunc func(admissionv1.AdmissionReview) *admissionv1.AdmissionResponse
+
+// AdmissionServiceConfig carries the shared clients and caches admission handlers need
+// so they don't have to hit the apiserver on every request.
+type AdmissionServiceConfig struct {
+	VolcanoClient vcclientset.Interface
+	KubeClient    kubernetes.Interface
+
+	// HyperNodeLister reads HyperNodes from the shared informer cache started by
+	// cmd/webhook-manager. It is nil until that informer's cache has synced, so
+	// callers must fall back to a live Get when it is unset or returns NotFound.
+	HyperNodeLister hypernodelisters.HyperNodeLister
+
+	// RegexMatchMaxLength bounds the length of a HyperNode RegexMatch pattern
+	// accepted at admission. Zero means the validating webhook's own default applies.
+	RegexMatchMaxLength int
+}
+
+// AdmissionService describes one admission webhook endpoint: the HTTP path it is
+// served on, the handler function, the shared config it reads from, and the
+// webhook configuration fragment it contributes.
+type AdmissionService struct {
+	Path string
+	Func AdmitFunc
+
+	Config *AdmissionServiceConfig
+
+	MutatingConfig   *whv1.MutatingWebhookConfiguration
+	ValidatingConfig *whv1.ValidatingWebhookConfiguration
+}
+
+var admissionServices []*AdmissionService
+
+// RegisterAdmission registers an admission service so cmd/webhook-manager can wire it
+// up to an HTTP handler and to the cluster's webhook configuration objects.
+func RegisterAdmission(service *AdmissionService) {
+	admissionServices = append(admissionServices, service)
+}
+
+// ForeachAdmission calls f for every registered admission service, in registration order.
+func ForeachAdmission(f func(*AdmissionService)) {
+	for _, service := range admissionServices {
+		f(service)
+	}
+}
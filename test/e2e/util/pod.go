@@ -2,6 +2,7 @@ package util
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/gomega"
@@ -18,39 +19,196 @@ type PodSpec struct {
 }
 
 func CreatePod(ctx *TestContext, spec PodSpec) *v1.Pod {
-	pod := &v1.Pod{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      spec.Name,
-			Namespace: ctx.Namespace,
-		},
-		Spec: v1.PodSpec{
-			NodeName: spec.Node,
-			Containers: []v1.Container{
-				{
-					Image:           DefaultNginxImage,
-					Name:            spec.Name,
-					ImagePullPolicy: v1.PullIfNotPresent,
-					Resources: v1.ResourceRequirements{
-						Requests: spec.Req,
+	pod := NewPodBuilder(spec.Name, ctx.Namespace).
+		WithNode(spec.Node).
+		WithResources(spec.Req).
+		WithTolerations(spec.Tolerations...).
+		Build()
+
+	pod, err := ctx.Kubeclient.CoreV1().Pods(ctx.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+	Expect(err).NotTo(HaveOccurred(), "failed to create pod %s", spec.Name)
+
+	return pod
+}
+
+// PodBuilder builds a v1.Pod fluently, for e2e suites (gang-scheduling, preemption,
+// hypernode topology, ...) that need more than a bare single-container nginx pod.
+type PodBuilder struct {
+	pod *v1.Pod
+}
+
+// NewPodBuilder starts a PodBuilder with a single container defaulting to
+// DefaultNginxImage, the way CreatePod's pods have always looked.
+func NewPodBuilder(name, namespace string) *PodBuilder {
+	return &PodBuilder{
+		pod: &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{
+					{
+						Name:            name,
+						Image:           DefaultNginxImage,
+						ImagePullPolicy: v1.PullIfNotPresent,
 					},
 				},
 			},
-			Tolerations: spec.Tolerations,
 		},
 	}
+}
 
-	pod, err := ctx.Kubeclient.CoreV1().Pods(ctx.Namespace).Create(context.TODO(), pod, metav1.CreateOptions{})
-	Expect(err).NotTo(HaveOccurred(), "failed to create pod %s", spec.Name)
+func (b *PodBuilder) WithImage(image string) *PodBuilder {
+	b.pod.Spec.Containers[0].Image = image
+	return b
+}
 
-	return pod
+func (b *PodBuilder) WithCommand(command ...string) *PodBuilder {
+	b.pod.Spec.Containers[0].Command = command
+	return b
 }
 
-func WaitPodReady(ctx *TestContext, pod *v1.Pod) error {
-	return wait.Poll(100*time.Millisecond, FiveMinute, func() (bool, error) {
-		pod, err := ctx.Kubeclient.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+func (b *PodBuilder) WithReadinessProbe(probe *v1.Probe) *PodBuilder {
+	b.pod.Spec.Containers[0].ReadinessProbe = probe
+	return b
+}
+
+func (b *PodBuilder) WithLivenessProbe(probe *v1.Probe) *PodBuilder {
+	b.pod.Spec.Containers[0].LivenessProbe = probe
+	return b
+}
+
+func (b *PodBuilder) WithVolume(volume v1.Volume, mount v1.VolumeMount) *PodBuilder {
+	b.pod.Spec.Volumes = append(b.pod.Spec.Volumes, volume)
+	b.pod.Spec.Containers[0].VolumeMounts = append(b.pod.Spec.Containers[0].VolumeMounts, mount)
+	return b
+}
+
+func (b *PodBuilder) WithLabels(labels map[string]string) *PodBuilder {
+	b.pod.Labels = labels
+	return b
+}
+
+func (b *PodBuilder) WithAnnotations(annotations map[string]string) *PodBuilder {
+	b.pod.Annotations = annotations
+	return b
+}
+
+func (b *PodBuilder) WithPriorityClass(name string) *PodBuilder {
+	b.pod.Spec.PriorityClassName = name
+	return b
+}
+
+func (b *PodBuilder) WithTopologyConstraints(constraints ...v1.TopologySpreadConstraint) *PodBuilder {
+	b.pod.Spec.TopologySpreadConstraints = constraints
+	return b
+}
+
+func (b *PodBuilder) WithNode(node string) *PodBuilder {
+	b.pod.Spec.NodeName = node
+	return b
+}
+
+func (b *PodBuilder) WithResources(req v1.ResourceList) *PodBuilder {
+	b.pod.Spec.Containers[0].Resources = v1.ResourceRequirements{Requests: req}
+	return b
+}
+
+func (b *PodBuilder) WithTolerations(tolerations ...v1.Toleration) *PodBuilder {
+	b.pod.Spec.Tolerations = tolerations
+	return b
+}
+
+func (b *PodBuilder) Build() *v1.Pod {
+	return b.pod
+}
+
+// WaitPodCondition polls the pod until condition reports true or FiveMinute elapses.
+// It fails fast on ImagePullBackOff/ErrImagePull instead of waiting out the full
+// timeout, and on any failure it surfaces the pod's last container-status
+// reason/message rather than a bare wait.ErrWaitTimeout.
+func WaitPodCondition(ctx *TestContext, pod *v1.Pod, condition func(*v1.Pod) (bool, error)) error {
+	var latest *v1.Pod
+
+	err := wait.Poll(100*time.Millisecond, FiveMinute, func() (bool, error) {
+		var err error
+		latest, err = ctx.Kubeclient.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
 		if err != nil {
 			return false, err
 		}
-		return pod.Status.Phase == v1.PodRunning, nil
+
+		if reason := podFailFastReason(latest); reason != "" {
+			return false, fmt.Errorf("pod %s/%s failed fast: %s", latest.Namespace, latest.Name, reason)
+		}
+
+		return condition(latest)
+	})
+
+	if err != nil && latest != nil {
+		return fmt.Errorf("%v: %s", err, podStatusSummary(latest))
+	}
+	return err
+}
+
+// podFailFastReason returns a non-empty reason when a container is stuck in a state
+// that will never resolve on its own, so waiters don't sit out the full timeout.
+func podFailFastReason(pod *v1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting == nil {
+			continue
+		}
+		switch cs.State.Waiting.Reason {
+		case "ImagePullBackOff", "ErrImagePull":
+			return fmt.Sprintf("container %s: %s: %s", cs.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message)
+		}
+	}
+	return ""
+}
+
+// podStatusSummary describes the pod's current state for timeout/failure errors.
+func podStatusSummary(pod *v1.Pod) string {
+	for _, cs := range pod.Status.ContainerStatuses {
+		switch {
+		case cs.State.Waiting != nil:
+			return fmt.Sprintf("container %s waiting: %s: %s", cs.Name, cs.State.Waiting.Reason, cs.State.Waiting.Message)
+		case cs.State.Terminated != nil:
+			return fmt.Sprintf("container %s terminated: %s: %s", cs.Name, cs.State.Terminated.Reason, cs.State.Terminated.Message)
+		}
+	}
+	return fmt.Sprintf("pod phase %s", pod.Status.Phase)
+}
+
+// WaitPodScheduled waits until the scheduler has bound the pod to a node.
+func WaitPodScheduled(ctx *TestContext, pod *v1.Pod) error {
+	return WaitPodCondition(ctx, pod, func(pod *v1.Pod) (bool, error) {
+		return pod.Spec.NodeName != "", nil
+	})
+}
+
+// WaitPodReady waits until the pod's PodReady condition is true, which (unlike
+// phase == PodRunning) also accounts for readiness probes.
+func WaitPodReady(ctx *TestContext, pod *v1.Pod) error {
+	return WaitPodCondition(ctx, pod, func(pod *v1.Pod) (bool, error) {
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == v1.PodReady {
+				return cond.Status == v1.ConditionTrue, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// WaitPodEvicted waits until the pod has been evicted by the kubelet.
+func WaitPodEvicted(ctx *TestContext, pod *v1.Pod) error {
+	return WaitPodCondition(ctx, pod, func(pod *v1.Pod) (bool, error) {
+		return pod.Status.Phase == v1.PodFailed && pod.Status.Reason == "Evicted", nil
+	})
+}
+
+// WaitPodTerminating waits until the pod has been marked for deletion.
+func WaitPodTerminating(ctx *TestContext, pod *v1.Pod) error {
+	return WaitPodCondition(ctx, pod, func(pod *v1.Pod) (bool, error) {
+		return pod.DeletionTimestamp != nil, nil
 	})
 }